@@ -0,0 +1,89 @@
+// Command sales-client reads output.csv line-by-line and streams it to
+// SalesService.AggregateSales, printing the aggregated result it gets back.
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/HenokTG/sales-data-csv-processer-grpc/server/pb"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "SalesService address")
+	inputFile := flag.String("input", "output.csv", "CSV file to push rows from")
+	groupBy := flag.String("group-by", "DEPARTMENT", "DEPARTMENT, DATE, or DEPARTMENT_AND_DATE")
+	reduction := flag.String("reduction", "SUM", "SUM, AVG, MIN, or MAX")
+	flag.Parse()
+
+	conn, err := grpc.NewClient(*addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(pb.Codec())),
+	)
+	if err != nil {
+		log.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewSalesServiceClient(conn)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "group-by", *groupBy, "reduction", *reduction)
+	stream, err := client.AggregateSales(ctx)
+	if err != nil {
+		log.Fatalf("aggregate sales: %v", err)
+	}
+
+	file, err := os.Open(*inputFile)
+	if err != nil {
+		log.Fatalf("open %s: %v", *inputFile, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(bufio.NewReader(file))
+	if _, err := reader.Read(); err != nil { // header
+		log.Fatalf("read header: %v", err)
+	}
+
+	sent := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Fatalf("read row: %v", err)
+		}
+
+		sales, err := strconv.Atoi(record[2])
+		if err != nil {
+			log.Fatalf("parse sales %q: %v", record[2], err)
+		}
+
+		row := &pb.SalesRow{Department: record[0], Date: record[1], Sales: int32(sales)}
+		if err := stream.Send(row); err != nil {
+			log.Fatalf("send row: %v", err)
+		}
+		sent++
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		log.Fatalf("close and recv: %v", err)
+	}
+
+	fmt.Printf("✅ Streamed %d rows, got %d groups back:\n", sent, len(resp.Groups))
+	for _, g := range resp.Groups {
+		fmt.Printf("  dept=%-15s date=%-10s value=%.2f count=%d\n", g.Department, g.Date, g.Value, g.Count)
+	}
+}
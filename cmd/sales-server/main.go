@@ -0,0 +1,33 @@
+// Command sales-server hosts the SalesService gRPC API over output.csv.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+
+	"github.com/HenokTG/sales-data-csv-processer-grpc/server"
+	"github.com/HenokTG/sales-data-csv-processer-grpc/server/pb"
+)
+
+func main() {
+	addr := flag.String("addr", ":50051", "listen address")
+	outputFile := flag.String("output", "output.csv", "CSV file to serve StreamSales from")
+	flag.Parse()
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.ForceServerCodec(pb.Codec()))
+	pb.RegisterSalesServiceServer(grpcServer, server.NewServer(*outputFile))
+
+	fmt.Printf("🚀 SalesService listening on %s (serving %s)\n", *addr, *outputFile)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("serve: %v", err)
+	}
+}
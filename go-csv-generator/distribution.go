@@ -0,0 +1,128 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	minSales = 10
+	maxSales = 500
+	numDays  = 365
+
+	// monthlyTrendFactor is how much the weighted date sampler's base
+	// weight grows from January to December, modeling gradual yearly
+	// growth on top of the weekly seasonality curve.
+	monthlyTrendFactor = 0.5
+)
+
+// rowGenerator samples one Record at a time for a single worker. It owns
+// its own *rand.Rand plus whatever Distribution state (Zipf table, date
+// weights) that worker needs, so workers don't share or contend on state.
+type rowGenerator struct {
+	rng            *rand.Rand
+	departments    []string
+	numDepartments int
+	baseDate       time.Time
+
+	dist Distribution
+
+	deptZipf *rand.Zipf
+
+	dateCumWeights []float64
+	dateTotal      float64
+}
+
+func newRowGenerator(rng *rand.Rand, departments []string, baseDate time.Time, dist Distribution) *rowGenerator {
+	g := &rowGenerator{
+		rng:            rng,
+		departments:    departments,
+		numDepartments: len(departments),
+		baseDate:       baseDate,
+		dist:           dist,
+	}
+
+	if dist.DeptZipfS > 1 {
+		v := dist.DeptZipfV
+		if v <= 0 {
+			v = 1
+		}
+		g.deptZipf = rand.NewZipf(rng, dist.DeptZipfS, v, uint64(g.numDepartments-1))
+	}
+
+	if dist.WeekdayWeights != ([7]float64{}) {
+		g.dateCumWeights, g.dateTotal = buildDateWeights(baseDate, dist.WeekdayWeights)
+	}
+
+	return g
+}
+
+// buildDateWeights precomputes a cumulative-weight table over the next
+// numDays days, combining the weekday seasonality curve with a linear
+// monthly trend, so sampling a skewed date is a single weighted draw.
+func buildDateWeights(baseDate time.Time, weekdayWeights [7]float64) ([]float64, float64) {
+	weights := make([]float64, numDays)
+	total := 0.0
+
+	for offset := 0; offset < numDays; offset++ {
+		date := baseDate.AddDate(0, 0, offset)
+
+		weekdayWeight := weekdayWeights[date.Weekday()]
+		if weekdayWeight <= 0 {
+			weekdayWeight = 1
+		}
+
+		monthlyTrend := 1 + monthlyTrendFactor*float64(offset)/float64(numDays)
+
+		total += weekdayWeight * monthlyTrend
+		weights[offset] = total
+	}
+
+	return weights, total
+}
+
+func (g *rowGenerator) sampleDepartmentIndex() int {
+	if g.deptZipf != nil {
+		return int(g.deptZipf.Uint64())
+	}
+	return g.rng.Intn(g.numDepartments)
+}
+
+func (g *rowGenerator) sampleDateOffset() int {
+	if g.dateCumWeights == nil {
+		return g.rng.Intn(numDays)
+	}
+
+	target := g.rng.Float64() * g.dateTotal
+	offset := 0
+	for offset < numDays-1 && g.dateCumWeights[offset] < target {
+		offset++
+	}
+	return offset
+}
+
+func (g *rowGenerator) sampleSales() int {
+	if g.dist.SalesLogNormalSigma <= 0 {
+		return g.rng.Intn(maxSales-minSales+1) + minSales
+	}
+
+	sample := math.Exp(g.rng.NormFloat64()*g.dist.SalesLogNormalSigma + g.dist.SalesLogNormalMu)
+	sales := int(math.Round(sample))
+
+	if sales < minSales {
+		sales = minSales
+	}
+	if sales > maxSales {
+		sales = maxSales
+	}
+	return sales
+}
+
+func (g *rowGenerator) next() Record {
+	return Record{
+		Department: g.departments[g.sampleDepartmentIndex()],
+		Date:       g.baseDate.AddDate(0, 0, g.sampleDateOffset()).Format("2006-01-02"),
+		Sales:      g.sampleSales(),
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bufio"
+	"container/heap"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// runExternalSort generates config.NumRecords rows in chunks of
+// config.ChunkLines, sorts each chunk in memory by config.SortKey, spills
+// the sorted chunk to a temp file, then merges all chunks into
+// config.OutputFile with a heap-based k-way merge so the result is
+// globally sorted without ever holding all records in memory at once.
+//
+// Generation goes through the same seeded rowGenerator as the standard
+// path, so external-sort runs are reproducible (Config.Seed) and respect
+// Config.Distribution; the final merged output goes through the same
+// RecordWriter, so it respects Config.Format/Config.Compression too. Only
+// the intermediate chunk files on disk are always plain CSV.
+func runExternalSort(config Config) error {
+	fmt.Printf("\nGenerating CSV (external sort)...\nRecords: %d\nDepartments: %d\nChunk size: %d\nOutput: %s\n\n",
+		config.NumRecords, config.NumDepartments, config.ChunkLines, config.OutputFile)
+
+	departments := make([]string, config.NumDepartments)
+	for i := 0; i < config.NumDepartments; i++ {
+		departments[i] = fmt.Sprintf("Department %d", i+1)
+	}
+
+	seed := seedSource(config.Seed)
+	rng := rand.New(rand.NewSource(seed))
+	baseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := newRowGenerator(rng, departments, baseDate, config.Distribution)
+	less := lessFuncFor(config.SortKey)
+
+	var chunkFiles []string
+	defer func() {
+		for _, name := range chunkFiles {
+			os.Remove(name)
+		}
+	}()
+
+	remaining := config.NumRecords
+	for remaining > 0 {
+		n := config.ChunkLines
+		if n > remaining {
+			n = remaining
+		}
+
+		chunk := make([]Record, n)
+		for i := 0; i < n; i++ {
+			chunk[i] = gen.next()
+		}
+
+		sort.Slice(chunk, func(i, j int) bool { return less(chunk[i], chunk[j]) })
+
+		name, err := writeChunk(config.TempDir, chunk)
+		if err != nil {
+			return fmt.Errorf("write chunk: %w", err)
+		}
+		chunkFiles = append(chunkFiles, name)
+
+		remaining -= n
+		fmt.Printf("Flushed chunk %d (%d rows)...\n", len(chunkFiles), n)
+	}
+
+	writer, err := newRecordWriter(config)
+	if err != nil {
+		return fmt.Errorf("create writer: %w", err)
+	}
+
+	if err := writer.WriteHeader(); err != nil {
+		return fmt.Errorf("write header: %w", err)
+	}
+
+	if err := mergeChunks(chunkFiles, writer, less); err != nil {
+		return fmt.Errorf("merge chunks: %w", err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("close writer: %w", err)
+	}
+
+	fmt.Println("✅ CSV generation completed.")
+	return nil
+}
+
+func writeChunk(dir string, chunk []Record) (string, error) {
+	f, err := os.CreateTemp(dir, "chunk-*.csv")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	writer := bufio.NewWriter(f)
+	for _, rec := range chunk {
+		fmt.Fprintf(writer, "%s,%s,%d\n", rec.Department, rec.Date, rec.Sales)
+	}
+	if err := writer.Flush(); err != nil {
+		return "", err
+	}
+
+	return f.Name(), nil
+}
+
+// chunkReader pairs a chunk's scanner with the index of the chunk it reads
+// from, so the heap can pull the next record from the same chunk a popped
+// record came from.
+type chunkReader struct {
+	scanner    *bufio.Scanner
+	file       *os.File
+	chunkIndex int
+}
+
+// heapItem is one candidate record sitting in the merge heap.
+type heapItem struct {
+	rec        Record
+	chunkIndex int
+}
+
+type recordHeap struct {
+	items []heapItem
+	less  func(a, b Record) bool
+}
+
+func (h *recordHeap) Len() int { return len(h.items) }
+func (h *recordHeap) Less(i, j int) bool {
+	return h.less(h.items[i].rec, h.items[j].rec)
+}
+func (h *recordHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *recordHeap) Push(x any)    { h.items = append(h.items, x.(heapItem)) }
+func (h *recordHeap) Pop() any {
+	old := h.items
+	n := len(old)
+	item := old[n-1]
+	h.items = old[:n-1]
+	return item
+}
+
+func mergeChunks(chunkFiles []string, writer RecordWriter, less func(a, b Record) bool) error {
+	readers := make([]*chunkReader, 0, len(chunkFiles))
+	for i, name := range chunkFiles {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		readers = append(readers, &chunkReader{scanner: bufio.NewScanner(f), file: f, chunkIndex: i})
+	}
+	defer func() {
+		for _, r := range readers {
+			r.file.Close()
+		}
+	}()
+
+	h := &recordHeap{less: less}
+	heap.Init(h)
+
+	for _, r := range readers {
+		rec, err := nextRecord(r)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if err == nil {
+			heap.Push(h, heapItem{rec: rec, chunkIndex: r.chunkIndex})
+		}
+	}
+
+	for h.Len() > 0 {
+		item := heap.Pop(h).(heapItem)
+		if err := writer.WriteRow(item.rec.Department, item.rec.Date, item.rec.Sales); err != nil {
+			return err
+		}
+
+		rec, err := nextRecord(readers[item.chunkIndex])
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if err == nil {
+			heap.Push(h, heapItem{rec: rec, chunkIndex: item.chunkIndex})
+		}
+	}
+
+	return nil
+}
+
+// nextRecord reads the next CSV line from the chunk reader. It returns
+// io.EOF once the reader is exhausted, at which point that chunk simply
+// stops contributing to the heap; any other error (a malformed line, an
+// unparseable sales field) is returned as-is so the merge aborts instead
+// of silently dropping the rest of the chunk.
+func nextRecord(r *chunkReader) (Record, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return Record{}, err
+		}
+		return Record{}, io.EOF
+	}
+
+	line := r.scanner.Text()
+	parts := strings.SplitN(line, ",", 3)
+	if len(parts) != 3 {
+		return Record{}, fmt.Errorf("malformed chunk line %q: expected 3 comma-separated fields", line)
+	}
+
+	sales, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return Record{}, fmt.Errorf("parse sales in chunk line %q: %w", line, err)
+	}
+
+	return Record{Department: parts[0], Date: parts[1], Sales: sales}, nil
+}
+
+func lessFuncFor(sortKey string) func(a, b Record) bool {
+	switch sortKey {
+	case "department,date":
+		return func(a, b Record) bool {
+			if a.Department != b.Department {
+				return a.Department < b.Department
+			}
+			return a.Date < b.Date
+		}
+	default: // "date,department"
+		return func(a, b Record) bool {
+			if a.Date != b.Date {
+				return a.Date < b.Date
+			}
+			return a.Department < b.Department
+		}
+	}
+}
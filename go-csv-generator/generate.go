@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"math/rand"
+	"time"
+)
+
+// goldenShardMultiplier decorrelates each shard's derived seed from the
+// next, so adjacent shards don't end up with near-identical sequences.
+const goldenShardMultiplier = 2654435761
+
+func seedSource(seed int64) int64 {
+	if seed != 0 {
+		return seed
+	}
+	return time.Now().UnixNano()
+}
+
+// shardSeed derives a worker's PRNG seed from the run seed and its shard
+// index so every shard is reproducible yet independent.
+func shardSeed(seed int64, shardID int) int64 {
+	return seed ^ int64(shardID)*goldenShardMultiplier
+}
+
+// generateSerial writes config.NumRecords rows straight to w using a single
+// PRNG. It exists alongside generateParallel so the two can be benchmarked
+// against each other.
+func generateSerial(config Config, departments []string, w RecordWriter) {
+	seed := seedSource(config.Seed)
+	rng := rand.New(rand.NewSource(seed))
+	baseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen := newRowGenerator(rng, departments, baseDate, config.Distribution)
+
+	for i := 0; i < config.NumRecords; i++ {
+		rec := gen.next()
+		w.WriteRow(rec.Department, rec.Date, rec.Sales)
+	}
+}
+
+// shardBatchSize bounds how many rows a shard goroutine generates (and, for
+// batchRecordWriters, formats) before handing them to the drain goroutine.
+// Batching keeps peak memory at O(shardBatchSize * numShards) instead of
+// O(NumRecords), since each shard's channel only ever holds a couple of
+// batches rather than every row it will ever produce.
+const shardBatchSize = 4096
+
+// shardBatch is one unit of work handed from a shard goroutine to the drain
+// goroutine. For a batchRecordWriter, raw holds rows pre-formatted by the
+// shard goroutine and records is nil; otherwise records holds the rows
+// as-is and formatting happens on the drain goroutine via w.WriteRow.
+type shardBatch struct {
+	raw     []byte
+	records []Record
+}
+
+// generateParallel splits config.NumRecords into config.Workers shards,
+// each generating its rows on its own goroutine (seeded deterministically
+// off config.Seed) and streaming them to the drain goroutine in bounded
+// batches over per-shard channels. The drain goroutine consumes shards in
+// order, so output is identical regardless of how the workers finish
+// scheduling, without having to buffer a whole shard's rows at once.
+//
+// When w is a batchRecordWriter (csvWriter, ndjsonWriter), shard goroutines
+// also format each batch into raw bytes, so the expensive formatting work
+// runs in parallel too rather than serially on the drain goroutine; the
+// drain goroutine just writes the bytes. Writers that can't support that
+// (parquetWriter, which owns its own compression/row-group state) still
+// parallelize PRNG sampling and have the drain goroutine call w.WriteRow.
+func generateParallel(config Config, departments []string, w RecordWriter) {
+	seed := seedSource(config.Seed)
+	baseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	numShards := config.Workers
+	if numShards > config.NumRecords {
+		numShards = config.NumRecords
+	}
+	if numShards < 1 {
+		numShards = 1
+	}
+
+	base := config.NumRecords / numShards
+	extra := config.NumRecords % numShards
+
+	bw, canFormat := w.(batchRecordWriter)
+
+	shardChans := make([]chan shardBatch, numShards)
+	for shardID := 0; shardID < numShards; shardID++ {
+		count := base
+		if shardID < extra {
+			count++
+		}
+
+		ch := make(chan shardBatch, 2)
+		shardChans[shardID] = ch
+
+		go func(shardID, count int, ch chan<- shardBatch) {
+			defer close(ch)
+
+			rng := rand.New(rand.NewSource(shardSeed(seed, shardID)))
+			gen := newRowGenerator(rng, departments, baseDate, config.Distribution)
+
+			remaining := count
+			for remaining > 0 {
+				n := shardBatchSize
+				if n > remaining {
+					n = remaining
+				}
+
+				if canFormat {
+					var buf bytes.Buffer
+					for i := 0; i < n; i++ {
+						rec := gen.next()
+						buf.Write(bw.formatRow(rec.Department, rec.Date, rec.Sales))
+					}
+					ch <- shardBatch{raw: buf.Bytes()}
+				} else {
+					records := make([]Record, n)
+					for i := 0; i < n; i++ {
+						records[i] = gen.next()
+					}
+					ch <- shardBatch{records: records}
+				}
+
+				remaining -= n
+			}
+		}(shardID, count, ch)
+	}
+
+	for _, ch := range shardChans {
+		for batch := range ch {
+			if canFormat {
+				bw.writeRaw(batch.raw)
+				continue
+			}
+			for _, rec := range batch.records {
+				w.WriteRow(rec.Department, rec.Date, rec.Sales)
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+// discardWriter is a RecordWriter that throws rows away, so the benchmarks
+// below measure generation cost, not I/O.
+type discardWriter struct{}
+
+func (discardWriter) WriteHeader() error                                { return nil }
+func (discardWriter) WriteRow(department, date string, sales int) error { return nil }
+func (discardWriter) Close() error                                      { return nil }
+
+func benchmarkConfig() (Config, []string) {
+	config := Config{
+		NumRecords:     200_000,
+		NumDepartments: 50,
+		Seed:           42,
+		Workers:        4,
+	}
+
+	departments := make([]string, config.NumDepartments)
+	for i := 0; i < config.NumDepartments; i++ {
+		departments[i] = "Department " + string(rune('A'+i%26))
+	}
+
+	return config, departments
+}
+
+func BenchmarkGenerateSerial(b *testing.B) {
+	config, departments := benchmarkConfig()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		generateSerial(config, departments, discardWriter{})
+	}
+}
+
+func BenchmarkGenerateParallel(b *testing.B) {
+	config, departments := benchmarkConfig()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		generateParallel(config, departments, discardWriter{})
+	}
+}
@@ -1,18 +1,77 @@
 package main
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"os"
-	"time"
+	"runtime"
 )
 
 type Config struct {
 	NumRecords     int    `json:"num_records"`
 	NumDepartments int    `json:"num_departments"`
 	OutputFile     string `json:"output_file"`
+
+	// Mode selects the generation strategy. "standard" (the default)
+	// streams rows straight to OutputFile. "external-sort" buffers
+	// ChunkLines records at a time, sorts each chunk by SortKey, and
+	// merges the sorted chunks into a globally sorted OutputFile.
+	Mode string `json:"mode"`
+
+	ChunkLines int    `json:"chunk_lines"`
+	SortKey    string `json:"sort_key"`
+	TempDir    string `json:"temp_dir"`
+
+	// Seed makes generation reproducible: the same Seed, NumRecords and
+	// Workers always produce byte-identical output. 0 falls back to a
+	// time-based seed.
+	Seed int64 `json:"seed"`
+
+	// Workers is the number of shards NumRecords is split across for
+	// parallel generation. 0 defaults to runtime.NumCPU().
+	Workers int `json:"workers"`
+
+	// Format is the output encoding: "csv" (the default), "ndjson", or
+	// "parquet".
+	Format string `json:"format"`
+
+	// Compression wraps the output file: "none" (the default), "gzip",
+	// or "zstd".
+	Compression string `json:"compression"`
+
+	// Distribution shapes how departments, dates, and sales counts are
+	// sampled. The zero value reproduces the historical uniform-random
+	// behavior.
+	Distribution Distribution `json:"distribution"`
+}
+
+// Distribution configures the non-uniform sampling used by randomRecord.
+// Leaving it unset (the zero value) falls back to uniform sampling for
+// every column.
+type Distribution struct {
+	// DeptZipfS and DeptZipfV parameterize a Zipf distribution
+	// (rand.NewZipf) over department index so a handful of departments
+	// dominate. DeptZipfS must be > 1 to take effect.
+	DeptZipfS float64 `json:"dept_zipf_s"`
+	DeptZipfV float64 `json:"dept_zipf_v"`
+
+	// SalesLogNormalMu and SalesLogNormalSigma parameterize a log-normal
+	// distribution over sales count, clamped to [minSales, maxSales].
+	// SalesLogNormalSigma must be > 0 to take effect.
+	SalesLogNormalMu    float64 `json:"sales_log_normal_mu"`
+	SalesLogNormalSigma float64 `json:"sales_log_normal_sigma"`
+
+	// WeekdayWeights biases which day of the week a generated date falls
+	// on (index 0 = Sunday, matching time.Weekday), e.g. to model weekend
+	// spikes. Left zeroed, every day of the week is equally likely.
+	WeekdayWeights [7]float64 `json:"weekday_weights"`
+}
+
+// Record is a single generated sales row: Department Name,Date,Number of Sales.
+type Record struct {
+	Department string
+	Date       string
+	Sales      int
 }
 
 func loadConfig() Config {
@@ -34,6 +93,27 @@ func loadConfig() Config {
 	if config.OutputFile == "" {
 		config.OutputFile = "output.csv"
 	}
+	if config.Mode == "" {
+		config.Mode = "standard"
+	}
+	if config.ChunkLines == 0 {
+		config.ChunkLines = 100_000
+	}
+	if config.SortKey == "" {
+		config.SortKey = "date,department"
+	}
+	if config.TempDir == "" {
+		config.TempDir = os.TempDir()
+	}
+	if config.Workers == 0 {
+		config.Workers = runtime.NumCPU()
+	}
+	if config.Format == "" {
+		config.Format = "csv"
+	}
+	if config.Compression == "" {
+		config.Compression = "none"
+	}
 
 	return config
 }
@@ -41,36 +121,38 @@ func loadConfig() Config {
 func main() {
 	config := loadConfig()
 
-	fmt.Printf("\nGenerating CSV...\nRecords: %d\nDepartments: %d\nOutput: %s\n\n",
-		config.NumRecords, config.NumDepartments, config.OutputFile)
+	if config.Mode == "external-sort" {
+		if err := runExternalSort(config); err != nil {
+			fmt.Printf("❌ external sort failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Printf("\nGenerating CSV...\nRecords: %d\nDepartments: %d\nWorkers: %d\nFormat: %s (%s)\nOutput: %s\n\n",
+		config.NumRecords, config.NumDepartments, config.Workers, config.Format, config.Compression, config.OutputFile)
 
 	departments := make([]string, config.NumDepartments)
 	for i := 0; i < config.NumDepartments; i++ {
 		departments[i] = fmt.Sprintf("Department %d", i+1)
 	}
 
-	file, _ := os.Create(config.OutputFile)
-	defer file.Close()
-	writer := bufio.NewWriter(file)
-
-	fmt.Fprintln(writer, "Department Name,Date,Number of Sales")
-
-	rand.Seed(time.Now().UnixNano())
-	baseDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	writer, err := newRecordWriter(config)
+	if err != nil {
+		fmt.Printf("❌ create writer: %v\n", err)
+		os.Exit(1)
+	}
 
-	for i := 0; i < config.NumRecords; i++ {
-		dept := departments[rand.Intn(config.NumDepartments)]
-		date := baseDate.AddDate(0, 0, rand.Intn(365))
-		sales := rand.Intn(491) + 10
+	if err := writer.WriteHeader(); err != nil {
+		fmt.Printf("❌ write header: %v\n", err)
+		os.Exit(1)
+	}
 
-		fmt.Fprintf(writer, "%s,%s,%d\n", dept, date.Format("2006-01-02"), sales)
+	generateParallel(config, departments, writer)
 
-		if i%1_000_000 == 0 {
-			writer.Flush()
-			fmt.Printf("Written %d rows...\n", i)
-		}
+	if err := writer.Close(); err != nil {
+		fmt.Printf("❌ close writer: %v\n", err)
+		os.Exit(1)
 	}
-
-	writer.Flush()
 	fmt.Println("✅ CSV generation completed.")
 }
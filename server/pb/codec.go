@@ -0,0 +1,30 @@
+package pb
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec marshals SalesService messages as JSON instead of real
+// protobuf wire format, since the types in sales.pb.go aren't generated
+// by protoc and don't implement proto.Message. It's registered under its
+// own content-subtype rather than "proto", so it never shadows grpc-go's
+// default codec for other services in the same process; callers opt in
+// explicitly with grpc.ForceServerCodec/grpc.ForceCodec(pb.Codec()).
+type jsonCodec struct{}
+
+// Codec returns the encoding.Codec SalesService clients and servers must
+// opt into (via grpc.ForceServerCodec/grpc.ForceCodec) to marshal the
+// hand-written types in this package.
+func Codec() encoding.Codec { return jsonCodec{} }
+
+func (jsonCodec) Marshal(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+func (jsonCodec) Name() string { return "sales-json" }
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
@@ -0,0 +1,50 @@
+// Package pb holds the SalesService wire types described by
+// proto/sales.proto. This environment has no protoc/protoc-gen-go
+// toolchain available, so these are hand-written rather than generated,
+// and are marshaled by the JSON codec registered in codec.go instead of
+// real protobuf wire format. Field names and shape intentionally mirror
+// proto/sales.proto so swapping in real protoc output later is a
+// drop-in replacement.
+package pb
+
+type GroupBy int32
+
+const (
+	GroupBy_DEPARTMENT          GroupBy = 0
+	GroupBy_DATE                GroupBy = 1
+	GroupBy_DEPARTMENT_AND_DATE GroupBy = 2
+)
+
+type Reduction int32
+
+const (
+	Reduction_SUM Reduction = 0
+	Reduction_AVG Reduction = 1
+	Reduction_MIN Reduction = 2
+	Reduction_MAX Reduction = 3
+)
+
+// SalesRow mirrors the record layout written by go-csv-generator
+// (Department Name,Date,Number of Sales).
+type SalesRow struct {
+	Department string `json:"department,omitempty"`
+	Date       string `json:"date,omitempty"`
+	Sales      int32  `json:"sales,omitempty"`
+}
+
+type AggregateResponse struct {
+	Groups []*AggregateResponse_Group `json:"groups,omitempty"`
+}
+
+type AggregateResponse_Group struct {
+	Department string  `json:"department,omitempty"`
+	Date       string  `json:"date,omitempty"`
+	Value      float64 `json:"value,omitempty"`
+	Count      int64   `json:"count,omitempty"`
+}
+
+type SalesQuery struct {
+	Department string `json:"department,omitempty"`
+	FromDate   string `json:"from_date,omitempty"`
+	ToDate     string `json:"to_date,omitempty"`
+}
@@ -0,0 +1,176 @@
+// Hand-written in the shape protoc-gen-go-grpc would produce for
+// proto/sales.proto (see sales.pb.go for why this isn't generated).
+
+package pb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+type SalesServiceClient interface {
+	AggregateSales(ctx context.Context, opts ...grpc.CallOption) (SalesService_AggregateSalesClient, error)
+	StreamSales(ctx context.Context, in *SalesQuery, opts ...grpc.CallOption) (SalesService_StreamSalesClient, error)
+}
+
+type salesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSalesServiceClient(cc grpc.ClientConnInterface) SalesServiceClient {
+	return &salesServiceClient{cc}
+}
+
+func (c *salesServiceClient) AggregateSales(ctx context.Context, opts ...grpc.CallOption) (SalesService_AggregateSalesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SalesService_ServiceDesc.Streams[0], "/sales.SalesService/AggregateSales", opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &salesServiceAggregateSalesClient{stream}, nil
+}
+
+type SalesService_AggregateSalesClient interface {
+	Send(*SalesRow) error
+	CloseAndRecv() (*AggregateResponse, error)
+	grpc.ClientStream
+}
+
+type salesServiceAggregateSalesClient struct {
+	grpc.ClientStream
+}
+
+func (x *salesServiceAggregateSalesClient) Send(m *SalesRow) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *salesServiceAggregateSalesClient) CloseAndRecv() (*AggregateResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(AggregateResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *salesServiceClient) StreamSales(ctx context.Context, in *SalesQuery, opts ...grpc.CallOption) (SalesService_StreamSalesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SalesService_ServiceDesc.Streams[1], "/sales.SalesService/StreamSales", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &salesServiceStreamSalesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SalesService_StreamSalesClient interface {
+	Recv() (*SalesRow, error)
+	grpc.ClientStream
+}
+
+type salesServiceStreamSalesClient struct {
+	grpc.ClientStream
+}
+
+func (x *salesServiceStreamSalesClient) Recv() (*SalesRow, error) {
+	m := new(SalesRow)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SalesServiceServer is the server API for SalesService.
+type SalesServiceServer interface {
+	AggregateSales(SalesService_AggregateSalesServer) error
+	StreamSales(*SalesQuery, SalesService_StreamSalesServer) error
+}
+
+// UnimplementedSalesServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedSalesServiceServer struct{}
+
+func (UnimplementedSalesServiceServer) AggregateSales(SalesService_AggregateSalesServer) error {
+	return nil
+}
+
+func (UnimplementedSalesServiceServer) StreamSales(*SalesQuery, SalesService_StreamSalesServer) error {
+	return nil
+}
+
+func RegisterSalesServiceServer(s grpc.ServiceRegistrar, srv SalesServiceServer) {
+	s.RegisterService(&SalesService_ServiceDesc, srv)
+}
+
+func _SalesService_AggregateSales_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SalesServiceServer).AggregateSales(&salesServiceAggregateSalesServer{stream})
+}
+
+type SalesService_AggregateSalesServer interface {
+	SendAndClose(*AggregateResponse) error
+	Recv() (*SalesRow, error)
+	grpc.ServerStream
+}
+
+type salesServiceAggregateSalesServer struct {
+	grpc.ServerStream
+}
+
+func (x *salesServiceAggregateSalesServer) SendAndClose(m *AggregateResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *salesServiceAggregateSalesServer) Recv() (*SalesRow, error) {
+	m := new(SalesRow)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SalesService_StreamSales_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SalesQuery)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SalesServiceServer).StreamSales(m, &salesServiceStreamSalesServer{stream})
+}
+
+type SalesService_StreamSalesServer interface {
+	Send(*SalesRow) error
+	grpc.ServerStream
+}
+
+type salesServiceStreamSalesServer struct {
+	grpc.ServerStream
+}
+
+func (x *salesServiceStreamSalesServer) Send(m *SalesRow) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// SalesService_ServiceDesc is the grpc.ServiceDesc for SalesService.
+var SalesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "sales.SalesService",
+	HandlerType: (*SalesServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "AggregateSales",
+			Handler:       _SalesService_AggregateSales_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamSales",
+			Handler:       _SalesService_StreamSales_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/sales.proto",
+}
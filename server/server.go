@@ -0,0 +1,219 @@
+// Package server implements the SalesService gRPC API on top of the CSV
+// records produced by go-csv-generator.
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/HenokTG/sales-data-csv-processer-grpc/server/pb"
+)
+
+// Server implements pb.SalesServiceServer.
+type Server struct {
+	pb.UnimplementedSalesServiceServer
+
+	// OutputFile is the CSV file StreamSales reads rows from, normally the
+	// file produced by go-csv-generator (output.csv).
+	OutputFile string
+}
+
+// NewServer returns a Server that serves rows out of outputFile.
+func NewServer(outputFile string) *Server {
+	return &Server{OutputFile: outputFile}
+}
+
+type aggKey struct {
+	department string
+	date       string
+}
+
+type aggAccumulator struct {
+	sum   int64
+	count int64
+	min   int64
+	max   int64
+}
+
+func (a *aggAccumulator) add(sales int32) {
+	v := int64(sales)
+	if a.count == 0 {
+		a.min, a.max = v, v
+	} else {
+		if v < a.min {
+			a.min = v
+		}
+		if v > a.max {
+			a.max = v
+		}
+	}
+	a.sum += v
+	a.count++
+}
+
+func (a *aggAccumulator) value(reduction pb.Reduction) float64 {
+	switch reduction {
+	case pb.Reduction_AVG:
+		if a.count == 0 {
+			return 0
+		}
+		return float64(a.sum) / float64(a.count)
+	case pb.Reduction_MIN:
+		return float64(a.min)
+	case pb.Reduction_MAX:
+		return float64(a.max)
+	default: // pb.Reduction_SUM
+		return float64(a.sum)
+	}
+}
+
+// AggregateSales accumulates the streamed rows and, once the client closes
+// the stream, returns the grouped result. The grouping and reduction are
+// read from the "group-by" and "reduction" incoming metadata keys and
+// default to DEPARTMENT and SUM.
+func (s *Server) AggregateSales(stream pb.SalesService_AggregateSalesServer) error {
+	groupBy, reduction := groupingFromContext(stream.Context())
+
+	groups := make(map[aggKey]*aggAccumulator)
+	order := make([]aggKey, 0)
+
+	for {
+		row, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		key := keyFor(row, groupBy)
+		acc, ok := groups[key]
+		if !ok {
+			acc = &aggAccumulator{}
+			groups[key] = acc
+			order = append(order, key)
+		}
+		acc.add(row.Sales)
+	}
+
+	resp := &pb.AggregateResponse{}
+	for _, key := range order {
+		acc := groups[key]
+		resp.Groups = append(resp.Groups, &pb.AggregateResponse_Group{
+			Department: key.department,
+			Date:       key.date,
+			Value:      acc.value(reduction),
+			Count:      acc.count,
+		})
+	}
+
+	return stream.SendAndClose(resp)
+}
+
+// StreamSales reads s.OutputFile and emits matching rows one at a time.
+func (s *Server) StreamSales(query *pb.SalesQuery, stream pb.SalesService_StreamSalesServer) error {
+	file, err := os.Open(s.OutputFile)
+	if err != nil {
+		return fmt.Errorf("open %s: %w", s.OutputFile, err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(bufio.NewReader(file))
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("read header: %w", err)
+	}
+	if len(header) != 3 {
+		return fmt.Errorf("unexpected column count %d in %s", len(header), s.OutputFile)
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		department, date, salesStr := record[0], record[1], record[2]
+		if query.Department != "" && query.Department != department {
+			continue
+		}
+		if query.FromDate != "" && date < query.FromDate {
+			continue
+		}
+		if query.ToDate != "" && date > query.ToDate {
+			continue
+		}
+
+		sales, err := strconv.Atoi(salesStr)
+		if err != nil {
+			return fmt.Errorf("parse sales %q: %w", salesStr, err)
+		}
+
+		row := &pb.SalesRow{Department: department, Date: date, Sales: int32(sales)}
+		if err := stream.Send(row); err != nil {
+			return err
+		}
+	}
+}
+
+func keyFor(row *pb.SalesRow, groupBy pb.GroupBy) aggKey {
+	switch groupBy {
+	case pb.GroupBy_DATE:
+		return aggKey{date: row.Date}
+	case pb.GroupBy_DEPARTMENT_AND_DATE:
+		return aggKey{department: row.Department, date: row.Date}
+	default: // pb.GroupBy_DEPARTMENT
+		return aggKey{department: row.Department}
+	}
+}
+
+func groupingFromContext(ctx context.Context) (pb.GroupBy, pb.Reduction) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return pb.GroupBy_DEPARTMENT, pb.Reduction_SUM
+	}
+	return parseGroupBy(firstOrEmpty(md.Get("group-by"))), parseReduction(firstOrEmpty(md.Get("reduction")))
+}
+
+func firstOrEmpty(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func parseGroupBy(v string) pb.GroupBy {
+	switch strings.ToUpper(v) {
+	case "DATE":
+		return pb.GroupBy_DATE
+	case "DEPARTMENT_AND_DATE":
+		return pb.GroupBy_DEPARTMENT_AND_DATE
+	default:
+		return pb.GroupBy_DEPARTMENT
+	}
+}
+
+func parseReduction(v string) pb.Reduction {
+	switch strings.ToUpper(v) {
+	case "AVG":
+		return pb.Reduction_AVG
+	case "MIN":
+		return pb.Reduction_MIN
+	case "MAX":
+		return pb.Reduction_MAX
+	default:
+		return pb.Reduction_SUM
+	}
+}